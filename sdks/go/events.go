@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Event represents a single entry appended to an event-store stream.
+type Event struct {
+	ID        string            `json:"id"`
+	StreamID  string            `json:"stream_id"`
+	EventType string            `json:"event_type"`
+	Data      json.RawMessage   `json:"data"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// EventBatch is the envelope GET /api/v1/events/{stream} responds with,
+// whether called directly (GetEventsTyped) or via long-poll (pollEvents).
+type EventBatch struct {
+	Events []Event `json:"events"`
+}
+
+// eventCursor remembers the last event ID delivered per stream, so
+// SubscribeEvents/TailEvents can resume after a reconnect without
+// redelivering or skipping events.
+type eventCursor struct {
+	mu   sync.Mutex
+	seen map[string]string
+}
+
+func (ec *eventCursor) get(streamID string) string {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return ec.seen[streamID]
+}
+
+func (ec *eventCursor) set(streamID, eventID string) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	if ec.seen == nil {
+		ec.seen = make(map[string]string)
+	}
+	ec.seen[streamID] = eventID
+}
+
+// longPollWait is how long the server may hold open a long-poll request
+// before returning an empty batch.
+const longPollWait = 30 * time.Second
+
+// SubscribeEvents delivers events appended to streamID to handler, resuming
+// after fromEventID (pass "" to start from the beginning of the stream). It
+// uses the resilient WebSocket connection when one is active, falling back
+// to HTTP long-polling against /api/v1/events/{stream} otherwise. Across
+// reconnects it resumes from the last event ID it delivered, so handler
+// sees each event exactly once and in order. SubscribeEvents blocks until
+// ctx is canceled or the underlying transport returns a non-recoverable
+// error.
+func (c *SyrosClient) SubscribeEvents(ctx context.Context, streamID, fromEventID string, handler func(Event)) error {
+	c.eventCursors.set(streamID, fromEventID)
+
+	if c.hasWebSocket() {
+		return c.subscribeEventsWS(ctx, streamID, handler)
+	}
+	return c.pollEvents(ctx, streamID, handler)
+}
+
+// TailEvents is a channel-based convenience wrapper around SubscribeEvents,
+// starting from the current end of streamID. The returned channel is closed
+// when ctx is canceled or the subscription ends with an error.
+func (c *SyrosClient) TailEvents(ctx context.Context, streamID string) (<-chan Event, error) {
+	existing, err := c.GetEventsTyped(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	from := ""
+	if n := len(*existing); n > 0 {
+		from = (*existing)[n-1].ID
+	}
+
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+		c.SubscribeEvents(ctx, streamID, from, func(evt Event) {
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return out, nil
+}
+
+// subscribeEventsWS subscribes to a stream's topic over the resilient
+// WebSocket connection. The subscribe payload carries the current resume
+// cursor so StartWebSocket's reconnect replay picks up where delivery left
+// off rather than from fromEventID again.
+func (c *SyrosClient) subscribeEventsWS(ctx context.Context, streamID string, handler func(Event)) error {
+	topic := "stream:" + streamID
+
+	err := c.subscribeWS(topic, func(msg []byte) {
+		var envelope struct {
+			Data Event `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &envelope); err != nil {
+			return
+		}
+		c.eventCursors.set(streamID, envelope.Data.ID)
+		handler(envelope.Data)
+	}, func() map[string]interface{} {
+		return map[string]interface{}{
+			"topic": topic,
+			"after": c.eventCursors.get(streamID),
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	c.Unsubscribe(topic)
+	return ctx.Err()
+}
+
+// pollEvents repeatedly long-polls /api/v1/events/{stream}, advancing the
+// resume cursor after each delivered event so a retried request (or a fresh
+// call to SubscribeEvents) continues from where it left off.
+func (c *SyrosClient) pollEvents(ctx context.Context, streamID string, handler func(Event)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		after := c.eventCursors.get(streamID)
+		query := url.Values{
+			"after": {after},
+			"wait":  {longPollWait.String()},
+		}
+		path := fmt.Sprintf("/api/v1/events/%s?%s", url.PathEscape(streamID), query.Encode())
+
+		resp, err := c.sendRestRequestRawCtx(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		var batch EventBatch
+		if err := json.Unmarshal([]byte(resp), &batch); err != nil {
+			return err
+		}
+
+		for _, evt := range batch.Events {
+			handler(evt)
+			c.eventCursors.set(streamID, evt.ID)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LockOptions configures AcquireSession and WithLock.
+type LockOptions struct {
+	// Owner identifies the holder of the lock, as passed to AcquireLock.
+	Owner string
+	// TTL is the lock's time-to-live on the server; LockSession renews it
+	// at TTL/3 intervals.
+	TTL time.Duration
+	// Metadata is optional opaque metadata stored alongside the lock.
+	Metadata *string
+}
+
+// LockSession is a distributed lock held by this client, kept alive by a
+// background goroutine that renews it at opts.TTL/3 intervals. If renewal
+// fails, or the context passed to AcquireSession is canceled, the session's
+// derived context is canceled so callers can bail out of their critical
+// section promptly.
+type LockSession struct {
+	client *SyrosClient
+	key    string
+	owner  string
+	lockID string
+	ttl    time.Duration
+
+	fencingToken int64 // accessed atomically
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	releaseOnce sync.Once
+	releaseErr  error
+}
+
+// AcquireSession acquires a distributed lock and starts a background
+// goroutine that renews it via POST /api/v1/locks/{key}/renew at
+// opts.TTL/3 intervals.
+func (c *SyrosClient) AcquireSession(ctx context.Context, key string, opts LockOptions) (*LockSession, error) {
+	ttlSeconds := int64(opts.TTL / time.Second)
+	lock, err := c.AcquireLockTyped(ctx, LockRequest{
+		Key:        key,
+		Owner:      opts.Owner,
+		TTLSeconds: &ttlSeconds,
+		Metadata:   opts.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	session := &LockSession{
+		client: c,
+		key:    key,
+		owner:  opts.Owner,
+		lockID: lock.LockID,
+		ttl:    opts.TTL,
+		ctx:    sessionCtx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	atomic.StoreInt64(&session.fencingToken, lock.FencingToken)
+
+	go session.renewLoop()
+	return session, nil
+}
+
+// WithLock acquires a session for key, runs fn with a context that is
+// canceled as soon as the lock can no longer be renewed or ctx itself is
+// canceled, and releases the lock afterwards regardless of fn's outcome.
+func (c *SyrosClient) WithLock(ctx context.Context, key string, opts LockOptions, fn func(ctx context.Context) error) error {
+	session, err := c.AcquireSession(ctx, key, opts)
+	if err != nil {
+		return err
+	}
+	defer session.Release()
+
+	return fn(session.Context())
+}
+
+// Context returns the session's derived context, canceled once the lock can
+// no longer be kept alive.
+func (s *LockSession) Context() context.Context {
+	return s.ctx
+}
+
+// FencingToken returns the monotonically increasing token issued by the
+// server on the most recent acquire or renew.
+func (s *LockSession) FencingToken() int64 {
+	return atomic.LoadInt64(&s.fencingToken)
+}
+
+// Release stops renewal and releases the underlying lock. It is idempotent
+// and safe to call from a defer even after the session's context has
+// already been canceled by a failed renewal.
+func (s *LockSession) Release() error {
+	s.releaseOnce.Do(func() {
+		s.cancel()
+		<-s.done
+		_, s.releaseErr = s.client.ReleaseLockContext(context.Background(), s.key, s.lockID, s.owner)
+	})
+	return s.releaseErr
+}
+
+func (s *LockSession) renewLoop() {
+	defer close(s.done)
+
+	interval := s.ttl / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.renew(); err != nil {
+				s.cancel()
+				return
+			}
+		}
+	}
+}
+
+func (s *LockSession) renew() error {
+	type renewResult struct {
+		FencingToken int64     `json:"fencing_token"`
+		ExpiresAt    time.Time `json:"expires_at"`
+	}
+
+	result, err := Do[renewResult](s.ctx, s.client, "POST", "/api/v1/locks/"+s.key+"/renew", map[string]interface{}{
+		"lock_id": s.lockID,
+		"owner":   s.owner,
+	})
+	if err != nil {
+		return err
+	}
+
+	atomic.StoreInt64(&s.fencingToken, result.FencingToken)
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestInterceptor is invoked on every outgoing REST request right before
+// it is sent, after the Idempotency-Key header has been set. Use it to plug
+// in auth (bearer/mTLS), tracing headers, or rate-limit shaping without
+// forking the SDK. Returning an error aborts the request.
+type RequestInterceptor func(*http.Request) error
+
+// RetryPolicy controls how sendRestRequestRawCtx retries failed REST
+// requests.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the exponential backoff (with jitter)
+	// used between attempts when the server doesn't send Retry-After.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// RetryableStatus lists the HTTP status codes worth retrying.
+	RetryableStatus map[int]bool
+}
+
+// DefaultRetryPolicy retries 429 and common gateway failures, making up to 3
+// attempts in total, with exponential backoff starting at 200ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:    true,
+			http.StatusBadGateway:         true,
+			http.StatusServiceUnavailable: true,
+			http.StatusGatewayTimeout:     true,
+		},
+	}
+}
+
+// HTTPError is returned when the server responds with a non-2xx status.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+// ClientOption configures optional behavior on a SyrosClient at
+// construction time.
+type ClientOption func(*SyrosClient)
+
+// WithRoundTripper overrides the http.RoundTripper used for REST requests,
+// e.g. to inject mTLS client certs or a custom dialer.
+func WithRoundTripper(rt http.RoundTripper) ClientOption {
+	return func(c *SyrosClient) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithRequestInterceptor sets a hook invoked on every outgoing REST request.
+func WithRequestInterceptor(interceptor RequestInterceptor) ClientOption {
+	return func(c *SyrosClient) {
+		c.interceptor = interceptor
+	}
+}
+
+// WithRetryPolicy overrides the default retry policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *SyrosClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithHTTPClient overrides the underlying http.Client entirely (e.g. to set
+// a custom Timeout).
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *SyrosClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// generateIdempotencyKey returns a random hex key sent as the
+// Idempotency-Key header on POST/DELETE requests, so a retried attempt is
+// recognized as the same operation by the server.
+func generateIdempotencyKey() string {
+	buf := make([]byte, 16)
+	if _, err := cryptorand.Read(buf); err != nil {
+		return fmt.Sprintf("fallback-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// backoffDelay returns an exponential backoff delay for the given attempt
+// number (1-indexed), with +/-25% jitter. The delay is capped at max before
+// the jitter is applied, so the result can reach max+25%.
+func backoffDelay(attempt int, base, max time.Duration) time.Duration {
+	delay := base << uint(attempt-1)
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2+1)) - delay/4
+	return delay + jitter
+}
+
+// parseRetryAfter parses a Retry-After header in either of the two forms
+// the spec allows: a delay in seconds, or an HTTP-date. It returns 0 if the
+// header is absent or matches neither form, leaving the caller to fall back
+// to its own backoff.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if when, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// sleepCtx waits for d or returns false early if ctx is canceled.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
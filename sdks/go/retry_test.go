@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelayCapsAtMax(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := 500 * time.Millisecond
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := backoffDelay(attempt, base, max)
+		if delay <= 0 {
+			t.Fatalf("attempt %d: delay = %v, want > 0", attempt, delay)
+		}
+		if delay > max+max/4 {
+			t.Fatalf("attempt %d: delay = %v, want <= max+jitter (%v)", attempt, delay, max+max/4)
+		}
+	}
+}
+
+func TestBackoffDelayGrows(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := 10 * time.Second
+
+	d1 := backoffDelay(1, base, max)
+	d5 := backoffDelay(5, base, max)
+	if d5 <= d1 {
+		t.Errorf("backoffDelay(5) = %v, want > backoffDelay(1) = %v", d5, d1)
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	got := parseRetryAfter("2")
+	if got != 2*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want 2s", "2", got)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(90 * time.Second).UTC()
+	header := future.Format(http.TimeFormat)
+
+	got := parseRetryAfter(header)
+	if got <= 0 || got > 95*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want a positive delay near 90s", header, got)
+	}
+}
+
+func TestParseRetryAfterEmptyOrMalformed(t *testing.T) {
+	for _, header := range []string{"", "not-a-date-or-number"} {
+		if got := parseRetryAfter(header); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %v, want 0", header, got)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SagaStepDef is one step of a SagaDefinition, as produced by SagaBuilder.
+type SagaStepDef struct {
+	Name         string
+	Action       string
+	Compensation string
+	RetryCount   int
+	Timeout      time.Duration
+}
+
+// SagaDefinition is a validated saga produced by SagaBuilder.Build, ready to
+// be passed to RunSaga.
+type SagaDefinition struct {
+	Name  string
+	Steps []SagaStepDef
+}
+
+// SagaBuilder fluently assembles a SagaDefinition:
+//
+//	def, err := NewSaga("order_checkout").
+//		Step("create_order").Action("orders.create").Compensation("orders.cancel").
+//		Then("charge_payment").Action("payments.charge").Compensation("payments.refund").Retry(3).Timeout(5*time.Second).
+//		Build()
+type SagaBuilder struct {
+	name    string
+	steps   []SagaStepDef
+	current *SagaStepDef
+	err     error
+}
+
+// NewSaga starts building a saga named name.
+func NewSaga(name string) *SagaBuilder {
+	return &SagaBuilder{name: name}
+}
+
+// Step begins a new step named name. Then is an alias for Step, used after
+// the first step to read as a chain of stages.
+func (b *SagaBuilder) Step(name string) *SagaBuilder {
+	b.commitCurrent()
+	b.current = &SagaStepDef{Name: name}
+	return b
+}
+
+// Then is an alias for Step, for a more narrative call chain.
+func (b *SagaBuilder) Then(name string) *SagaBuilder {
+	return b.Step(name)
+}
+
+// Action sets the action identifier the orchestrator invokes for the
+// current step.
+func (b *SagaBuilder) Action(action string) *SagaBuilder {
+	if b.current == nil {
+		b.err = fmt.Errorf("saga %q: Action called before Step", b.name)
+		return b
+	}
+	b.current.Action = action
+	return b
+}
+
+// Compensation sets the compensating action the orchestrator invokes if a
+// later step fails.
+func (b *SagaBuilder) Compensation(compensation string) *SagaBuilder {
+	if b.current == nil {
+		b.err = fmt.Errorf("saga %q: Compensation called before Step", b.name)
+		return b
+	}
+	b.current.Compensation = compensation
+	return b
+}
+
+// Retry sets the number of times the orchestrator retries the current step
+// on failure before giving up and compensating.
+func (b *SagaBuilder) Retry(n int) *SagaBuilder {
+	if b.current == nil {
+		b.err = fmt.Errorf("saga %q: Retry called before Step", b.name)
+		return b
+	}
+	b.current.RetryCount = n
+	return b
+}
+
+// Timeout sets the current step's execution timeout.
+func (b *SagaBuilder) Timeout(d time.Duration) *SagaBuilder {
+	if b.current == nil {
+		b.err = fmt.Errorf("saga %q: Timeout called before Step", b.name)
+		return b
+	}
+	b.current.Timeout = d
+	return b
+}
+
+func (b *SagaBuilder) commitCurrent() {
+	if b.current != nil {
+		b.steps = append(b.steps, *b.current)
+		b.current = nil
+	}
+}
+
+// Build validates the accumulated steps and returns the resulting
+// SagaDefinition.
+func (b *SagaBuilder) Build() (*SagaDefinition, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.commitCurrent()
+
+	if b.name == "" {
+		return nil, fmt.Errorf("saga: name is required")
+	}
+	if len(b.steps) == 0 {
+		return nil, fmt.Errorf("saga %q: at least one step is required", b.name)
+	}
+	for _, step := range b.steps {
+		if step.Action == "" {
+			return nil, fmt.Errorf("saga %q: step %q has no action", b.name, step.Name)
+		}
+	}
+
+	return &SagaDefinition{Name: b.name, Steps: b.steps}, nil
+}
+
+// SagaResult is the terminal outcome of RunSaga. Its Steps reuse
+// SagaStepStatus, the same per-step shape GetSagaStatusTyped returns.
+type SagaResult struct {
+	SagaID string           `json:"saga_id"`
+	Status string           `json:"status"`
+	Steps  []SagaStepStatus `json:"steps"`
+}
+
+var terminalSagaStatuses = map[string]bool{
+	"completed":   true,
+	"failed":      true,
+	"compensated": true,
+}
+
+// RunSagaOption configures RunSaga.
+type RunSagaOption func(*runSagaConfig)
+
+type runSagaConfig struct {
+	onStepChange func(SagaStepStatus)
+	pollInterval time.Duration
+}
+
+// WithOnStepChange registers a callback invoked every time a step's status
+// changes while RunSaga is waiting for the saga to reach a terminal state.
+func WithOnStepChange(fn func(SagaStepStatus)) RunSagaOption {
+	return func(cfg *runSagaConfig) { cfg.onStepChange = fn }
+}
+
+// WithPollInterval overrides how often RunSaga polls GetSagaStatusTyped when
+// no WebSocket connection is active. Default is 1 second.
+func WithPollInterval(d time.Duration) RunSagaOption {
+	return func(cfg *runSagaConfig) { cfg.pollInterval = d }
+}
+
+// RunSaga starts def and blocks until it reaches a terminal state
+// ("completed", "failed", or "compensated"), streaming per-step status
+// changes to an optional WithOnStepChange callback. It subscribes over the
+// resilient WebSocket connection when one is active, falling back to
+// polling GetSagaStatusTyped otherwise.
+func (c *SyrosClient) RunSaga(ctx context.Context, def *SagaDefinition, opts ...RunSagaOption) (*SagaResult, error) {
+	cfg := runSagaConfig{pollInterval: time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	steps := make([]map[string]interface{}, len(def.Steps))
+	for i, step := range def.Steps {
+		steps[i] = map[string]interface{}{
+			"name":            step.Name,
+			"action":          step.Action,
+			"compensation":    step.Compensation,
+			"retry":           step.RetryCount,
+			"timeout_seconds": int64(step.Timeout / time.Second),
+		}
+	}
+
+	saga, err := c.StartSagaTyped(ctx, def.Name, steps, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.hasWebSocket() {
+		return c.watchSagaWS(ctx, saga.SagaID, cfg)
+	}
+	return c.pollSagaStatus(ctx, saga.SagaID, cfg)
+}
+
+func (c *SyrosClient) watchSagaWS(ctx context.Context, sagaID string, cfg runSagaConfig) (*SagaResult, error) {
+	topic := "saga:" + sagaID
+	resultCh := make(chan *SagaResult, 1)
+	seen := make(map[string]string)
+
+	err := c.subscribeWS(topic, func(msg []byte) {
+		var envelope struct {
+			Data SagaResult `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &envelope); err != nil {
+			return
+		}
+
+		notifyStepChanges(cfg.onStepChange, seen, envelope.Data.Steps)
+
+		if terminalSagaStatuses[envelope.Data.Status] {
+			select {
+			case resultCh <- &envelope.Data:
+			default:
+			}
+		}
+	}, func() map[string]interface{} {
+		return map[string]interface{}{"topic": topic}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer c.Unsubscribe(topic)
+
+	// The saga may have already reached a terminal state between
+	// StartSagaTyped returning and the subscribe message above reaching the
+	// server, in which case the terminal notification was never delivered
+	// to us. Check once up front to close that race.
+	if status, err := c.GetSagaStatusTyped(ctx, sagaID); err == nil && terminalSagaStatuses[status.Status] {
+		result := &SagaResult{SagaID: status.SagaID, Status: status.Status, Steps: status.Steps}
+		notifyStepChanges(cfg.onStepChange, seen, result.Steps)
+		return result, nil
+	}
+
+	select {
+	case result := <-resultCh:
+		return result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *SyrosClient) pollSagaStatus(ctx context.Context, sagaID string, cfg runSagaConfig) (*SagaResult, error) {
+	seen := make(map[string]string)
+	ticker := time.NewTicker(cfg.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.GetSagaStatusTyped(ctx, sagaID)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &SagaResult{SagaID: status.SagaID, Status: status.Status, Steps: status.Steps}
+		notifyStepChanges(cfg.onStepChange, seen, result.Steps)
+
+		if terminalSagaStatuses[result.Status] {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func notifyStepChanges(onStepChange func(SagaStepStatus), seen map[string]string, steps []SagaStepStatus) {
+	if onStepChange == nil {
+		return
+	}
+	for _, step := range steps {
+		if seen[step.Name] == step.Status {
+			continue
+		}
+		seen[step.Name] = step.Status
+		onStepChange(step)
+	}
+}
+
+// RegisterAction registers a local handler that the orchestrator invokes
+// for saga steps whose action is action, delivered as callback messages
+// over the resilient WebSocket connection. The handler's result (or error)
+// is sent back to the server, closing the loop between the saga definition
+// and step execution running in this process.
+func (c *SyrosClient) RegisterAction(action string, handler func(ctx context.Context, payload json.RawMessage) (json.RawMessage, error)) error {
+	topic := "saga-action:" + action
+
+	return c.subscribeWS(topic, func(msg []byte) {
+		var envelope struct {
+			Data struct {
+				CallbackID string          `json:"callback_id"`
+				SagaID     string          `json:"saga_id"`
+				Step       string          `json:"step"`
+				Payload    json.RawMessage `json:"payload"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &envelope); err != nil {
+			return
+		}
+
+		result, err := handler(context.Background(), envelope.Data.Payload)
+		response := map[string]interface{}{
+			"callback_id": envelope.Data.CallbackID,
+			"saga_id":     envelope.Data.SagaID,
+			"step":        envelope.Data.Step,
+			"result":      result,
+		}
+		if err != nil {
+			response["error"] = err.Error()
+		}
+		c.sendWSMessage("saga_action_result", response)
+	}, func() map[string]interface{} {
+		return map[string]interface{}{"topic": topic}
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSagaBuilderBuild(t *testing.T) {
+	def, err := NewSaga("order_checkout").
+		Step("create_order").Action("orders.create").Compensation("orders.cancel").
+		Then("charge_payment").Action("payments.charge").Retry(3).Timeout(5 * time.Second).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() returned unexpected error: %v", err)
+	}
+	if def.Name != "order_checkout" {
+		t.Errorf("Name = %q, want %q", def.Name, "order_checkout")
+	}
+	if len(def.Steps) != 2 {
+		t.Fatalf("len(Steps) = %d, want 2", len(def.Steps))
+	}
+	if def.Steps[1].RetryCount != 3 || def.Steps[1].Timeout != 5*time.Second {
+		t.Errorf("second step = %+v, want RetryCount=3 Timeout=5s", def.Steps[1])
+	}
+}
+
+func TestSagaBuilderBuildRejectsMissingName(t *testing.T) {
+	_, err := NewSaga("").Step("s1").Action("a").Build()
+	if err == nil {
+		t.Fatal("Build() with empty name: expected error, got nil")
+	}
+}
+
+func TestSagaBuilderBuildRejectsNoSteps(t *testing.T) {
+	_, err := NewSaga("saga").Build()
+	if err == nil {
+		t.Fatal("Build() with no steps: expected error, got nil")
+	}
+}
+
+func TestSagaBuilderBuildRejectsMissingAction(t *testing.T) {
+	_, err := NewSaga("saga").Step("s1").Build()
+	if err == nil {
+		t.Fatal("Build() with a step missing Action: expected error, got nil")
+	}
+}
+
+func TestNotifyStepChangesDedup(t *testing.T) {
+	var delivered []SagaStepStatus
+	onChange := func(s SagaStepStatus) { delivered = append(delivered, s) }
+	seen := make(map[string]string)
+
+	notifyStepChanges(onChange, seen, []SagaStepStatus{
+		{Name: "create_order", Status: "running"},
+		{Name: "charge_payment", Status: "pending"},
+	})
+	notifyStepChanges(onChange, seen, []SagaStepStatus{
+		{Name: "create_order", Status: "running"}, // unchanged, should not be redelivered
+		{Name: "charge_payment", Status: "completed"},
+	})
+
+	if len(delivered) != 3 {
+		t.Fatalf("delivered = %d callbacks, want 3: %+v", len(delivered), delivered)
+	}
+	if delivered[2].Name != "charge_payment" || delivered[2].Status != "completed" {
+		t.Errorf("delivered[2] = %+v, want charge_payment/completed", delivered[2])
+	}
+}
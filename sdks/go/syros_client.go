@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -19,6 +21,24 @@ type SyrosClient struct {
 	restURL    string
 	wsURL      string
 	wsConn     *websocket.Conn
+
+	// retryPolicy and interceptor configure the REST transport; see
+	// retry.go and the With* ClientOptions.
+	retryPolicy RetryPolicy
+	interceptor RequestInterceptor
+
+	// Resilient WebSocket state (see websocket.go). wsMu guards wsConn,
+	// wsStopCh, wsSendCh and wsLoopDone; subsMu guards subscriptions.
+	wsMu       sync.Mutex
+	wsOpts     WebSocketOptions
+	wsStopCh   chan struct{}
+	wsSendCh   chan wsOutbound
+	wsLoopDone chan struct{}
+
+	subsMu        sync.RWMutex
+	subscriptions map[string]*wsSubscription
+
+	eventCursors eventCursor
 }
 
 // NewSyrosClient creates a new Syros client with default settings
@@ -27,23 +47,40 @@ func NewSyrosClient() *SyrosClient {
 }
 
 // NewSyrosClientWithURLs creates a new Syros client with custom URLs
-func NewSyrosClientWithURLs(restURL, wsURL string) *SyrosClient {
-	return &SyrosClient{
+func NewSyrosClientWithURLs(restURL, wsURL string, opts ...ClientOption) *SyrosClient {
+	c := &SyrosClient{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		restURL: restURL,
-		wsURL:   wsURL,
+		restURL:     restURL,
+		wsURL:       wsURL,
+		retryPolicy: DefaultRetryPolicy(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // HealthCheck performs a health check
 func (c *SyrosClient) HealthCheck() (map[string]interface{}, error) {
-	return c.sendRestRequest("GET", "/health", nil)
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext performs a health check, honoring ctx for cancellation
+// and deadlines.
+func (c *SyrosClient) HealthCheckContext(ctx context.Context) (map[string]interface{}, error) {
+	return c.sendRestRequestCtx(ctx, "GET", "/health", nil)
 }
 
 // AcquireLock acquires a distributed lock
 func (c *SyrosClient) AcquireLock(key, owner string, ttlSeconds *int64, metadata *string) (map[string]interface{}, error) {
+	return c.AcquireLockContext(context.Background(), key, owner, ttlSeconds, metadata)
+}
+
+// AcquireLockContext acquires a distributed lock, honoring ctx for
+// cancellation, deadlines, and retry/backoff.
+func (c *SyrosClient) AcquireLockContext(ctx context.Context, key, owner string, ttlSeconds *int64, metadata *string) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"key":   key,
 		"owner": owner,
@@ -55,26 +92,41 @@ func (c *SyrosClient) AcquireLock(key, owner string, ttlSeconds *int64, metadata
 		payload["metadata"] = *metadata
 	}
 
-	return c.sendRestRequest("POST", "/api/v1/locks", payload)
+	return c.sendRestRequestCtx(ctx, "POST", "/api/v1/locks", payload)
 }
 
 // ReleaseLock releases a distributed lock
 func (c *SyrosClient) ReleaseLock(key, lockID, owner string) (map[string]interface{}, error) {
+	return c.ReleaseLockContext(context.Background(), key, lockID, owner)
+}
+
+// ReleaseLockContext releases a distributed lock, honoring ctx.
+func (c *SyrosClient) ReleaseLockContext(ctx context.Context, key, lockID, owner string) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"lock_id": lockID,
 		"owner":   owner,
 	}
 
-	return c.sendRestRequest("DELETE", "/api/v1/locks/"+key, payload)
+	return c.sendRestRequestCtx(ctx, "DELETE", "/api/v1/locks/"+key, payload)
 }
 
 // GetLockStatus gets the status of a lock
 func (c *SyrosClient) GetLockStatus(key string) (map[string]interface{}, error) {
-	return c.sendRestRequest("GET", "/api/v1/locks/"+key+"/status", nil)
+	return c.GetLockStatusContext(context.Background(), key)
+}
+
+// GetLockStatusContext gets the status of a lock, honoring ctx.
+func (c *SyrosClient) GetLockStatusContext(ctx context.Context, key string) (map[string]interface{}, error) {
+	return c.sendRestRequestCtx(ctx, "GET", "/api/v1/locks/"+key+"/status", nil)
 }
 
 // StartSaga starts a new saga
 func (c *SyrosClient) StartSaga(name string, steps []map[string]interface{}, metadata map[string]string) (map[string]interface{}, error) {
+	return c.StartSagaContext(context.Background(), name, steps, metadata)
+}
+
+// StartSagaContext starts a new saga, honoring ctx.
+func (c *SyrosClient) StartSagaContext(ctx context.Context, name string, steps []map[string]interface{}, metadata map[string]string) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"name":  name,
 		"steps": steps,
@@ -83,16 +135,26 @@ func (c *SyrosClient) StartSaga(name string, steps []map[string]interface{}, met
 		payload["metadata"] = metadata
 	}
 
-	return c.sendRestRequest("POST", "/api/v1/sagas", payload)
+	return c.sendRestRequestCtx(ctx, "POST", "/api/v1/sagas", payload)
 }
 
 // GetSagaStatus gets the status of a saga
 func (c *SyrosClient) GetSagaStatus(sagaID string) (map[string]interface{}, error) {
-	return c.sendRestRequest("GET", "/api/v1/sagas/"+sagaID+"/status", nil)
+	return c.GetSagaStatusContext(context.Background(), sagaID)
+}
+
+// GetSagaStatusContext gets the status of a saga, honoring ctx.
+func (c *SyrosClient) GetSagaStatusContext(ctx context.Context, sagaID string) (map[string]interface{}, error) {
+	return c.sendRestRequestCtx(ctx, "GET", "/api/v1/sagas/"+sagaID+"/status", nil)
 }
 
 // AppendEvent appends an event to the event store
 func (c *SyrosClient) AppendEvent(streamID, eventType string, data interface{}, metadata map[string]string) (map[string]interface{}, error) {
+	return c.AppendEventContext(context.Background(), streamID, eventType, data, metadata)
+}
+
+// AppendEventContext appends an event to the event store, honoring ctx.
+func (c *SyrosClient) AppendEventContext(ctx context.Context, streamID, eventType string, data interface{}, metadata map[string]string) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"stream_id":  streamID,
 		"event_type": eventType,
@@ -102,16 +164,26 @@ func (c *SyrosClient) AppendEvent(streamID, eventType string, data interface{},
 		payload["metadata"] = metadata
 	}
 
-	return c.sendRestRequest("POST", "/api/v1/events", payload)
+	return c.sendRestRequestCtx(ctx, "POST", "/api/v1/events", payload)
 }
 
 // GetEvents gets events from the event store
 func (c *SyrosClient) GetEvents(streamID string) (map[string]interface{}, error) {
-	return c.sendRestRequest("GET", "/api/v1/events/"+streamID, nil)
+	return c.GetEventsContext(context.Background(), streamID)
+}
+
+// GetEventsContext gets events from the event store, honoring ctx.
+func (c *SyrosClient) GetEventsContext(ctx context.Context, streamID string) (map[string]interface{}, error) {
+	return c.sendRestRequestCtx(ctx, "GET", "/api/v1/events/"+streamID, nil)
 }
 
 // SetCache sets a value in the cache
 func (c *SyrosClient) SetCache(key string, value interface{}, ttlSeconds *int64, tags []string) (map[string]interface{}, error) {
+	return c.SetCacheContext(context.Background(), key, value, ttlSeconds, tags)
+}
+
+// SetCacheContext sets a value in the cache, honoring ctx.
+func (c *SyrosClient) SetCacheContext(ctx context.Context, key string, value interface{}, ttlSeconds *int64, tags []string) (map[string]interface{}, error) {
 	payload := map[string]interface{}{
 		"value": value,
 	}
@@ -122,26 +194,37 @@ func (c *SyrosClient) SetCache(key string, value interface{}, ttlSeconds *int64,
 		payload["tags"] = tags
 	}
 
-	return c.sendRestRequest("POST", "/api/v1/cache/"+key, payload)
+	return c.sendRestRequestCtx(ctx, "POST", "/api/v1/cache/"+key, payload)
 }
 
 // GetCache gets a value from the cache
 func (c *SyrosClient) GetCache(key string) (map[string]interface{}, error) {
-	return c.sendRestRequest("GET", "/api/v1/cache/"+key, nil)
+	return c.GetCacheContext(context.Background(), key)
+}
+
+// GetCacheContext gets a value from the cache, honoring ctx.
+func (c *SyrosClient) GetCacheContext(ctx context.Context, key string) (map[string]interface{}, error) {
+	return c.sendRestRequestCtx(ctx, "GET", "/api/v1/cache/"+key, nil)
 }
 
 // DeleteCache deletes a value from the cache
 func (c *SyrosClient) DeleteCache(key string) (map[string]interface{}, error) {
-	return c.sendRestRequest("DELETE", "/api/v1/cache/"+key, nil)
+	return c.DeleteCacheContext(context.Background(), key)
+}
+
+// DeleteCacheContext deletes a value from the cache, honoring ctx.
+func (c *SyrosClient) DeleteCacheContext(ctx context.Context, key string) (map[string]interface{}, error) {
+	return c.sendRestRequestCtx(ctx, "DELETE", "/api/v1/cache/"+key, nil)
 }
 
 // GetMetrics gets Prometheus metrics
 func (c *SyrosClient) GetMetrics() (string, error) {
-	resp, err := c.sendRestRequestRaw("GET", "/metrics", nil)
-	if err != nil {
-		return "", err
-	}
-	return resp, nil
+	return c.GetMetricsContext(context.Background())
+}
+
+// GetMetricsContext gets Prometheus metrics, honoring ctx.
+func (c *SyrosClient) GetMetricsContext(ctx context.Context) (string, error) {
+	return c.sendRestRequestRawCtx(ctx, "GET", "/metrics", nil)
 }
 
 // ConnectWebSocket connects to the WebSocket
@@ -151,18 +234,30 @@ func (c *SyrosClient) ConnectWebSocket() error {
 		return err
 	}
 
-	c.wsConn, _, err = websocket.DefaultDialer.Dial(u.String(), nil)
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
 	if err != nil {
 		return err
 	}
 
+	c.wsMu.Lock()
+	c.wsConn = conn
+	c.wsMu.Unlock()
+
 	fmt.Println("WebSocket connected to", c.wsURL)
 	return nil
 }
 
 // SendWebSocketMessage sends a message through WebSocket
 func (c *SyrosClient) SendWebSocketMessage(messageType string, data interface{}) error {
-	if c.wsConn == nil {
+	c.wsMu.Lock()
+	conn := c.wsConn
+	resilient := c.wsStopCh != nil
+	c.wsMu.Unlock()
+	if resilient {
+		// Queue for the session goroutine, the resilient connection's only writer.
+		return c.sendWSMessage(messageType, data)
+	}
+	if conn == nil {
 		return fmt.Errorf("WebSocket not connected")
 	}
 
@@ -171,17 +266,20 @@ func (c *SyrosClient) SendWebSocketMessage(messageType string, data interface{})
 		"data": data,
 	}
 
-	return c.wsConn.WriteJSON(message)
+	return conn.WriteJSON(message)
 }
 
 // ListenWebSocket listens for WebSocket messages
 func (c *SyrosClient) ListenWebSocket(onMessage func(string)) error {
-	if c.wsConn == nil {
+	c.wsMu.Lock()
+	conn := c.wsConn
+	c.wsMu.Unlock()
+	if conn == nil {
 		return fmt.Errorf("WebSocket not connected")
 	}
 
 	for {
-		_, message, err := c.wsConn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
 			return err
 		}
@@ -191,13 +289,17 @@ func (c *SyrosClient) ListenWebSocket(onMessage func(string)) error {
 
 // DisconnectWebSocket disconnects from WebSocket
 func (c *SyrosClient) DisconnectWebSocket() error {
-	if c.wsConn != nil {
-		err := c.wsConn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.wsMu.Lock()
+	conn := c.wsConn
+	c.wsConn = nil
+	c.wsMu.Unlock()
+
+	if conn != nil {
+		err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
 		if err != nil {
 			return err
 		}
-		c.wsConn.Close()
-		c.wsConn = nil
+		conn.Close()
 		fmt.Println("WebSocket disconnected")
 	}
 	return nil
@@ -205,14 +307,17 @@ func (c *SyrosClient) DisconnectWebSocket() error {
 
 // Close closes the client and releases resources
 func (c *SyrosClient) Close() error {
+	c.StopWebSocket()
 	c.DisconnectWebSocket()
 	return nil
 }
 
 // Private helper methods
 
-func (c *SyrosClient) sendRestRequest(method, path string, data interface{}) (map[string]interface{}, error) {
-	resp, err := c.sendRestRequestRaw(method, path, data)
+// sendRestRequestCtx sends a request and decodes the JSON response into a
+// map, honoring ctx for cancellation, deadlines, and retry/backoff.
+func (c *SyrosClient) sendRestRequestCtx(ctx context.Context, method, path string, data interface{}) (map[string]interface{}, error) {
+	resp, err := c.sendRestRequestRawCtx(ctx, method, path, data)
 	if err != nil {
 		return nil, err
 	}
@@ -225,41 +330,96 @@ func (c *SyrosClient) sendRestRequest(method, path string, data interface{}) (ma
 	return result, nil
 }
 
-func (c *SyrosClient) sendRestRequestRaw(method, path string, data interface{}) (string, error) {
-	url := c.restURL + path
-	var body io.Reader
+// sendRestRequestRawCtx is the low-level REST entry point: it applies the
+// client's RequestInterceptor, retries retryable failures per retryPolicy
+// (honoring Retry-After), and returns the raw response body. See retry.go.
+func (c *SyrosClient) sendRestRequestRawCtx(ctx context.Context, method, path string, data interface{}) (string, error) {
+	reqURL := c.restURL + path
 
+	var jsonData []byte
 	if data != nil {
-		jsonData, err := json.Marshal(data)
+		var err error
+		jsonData, err = json.Marshal(data)
 		if err != nil {
 			return "", err
 		}
-		body = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), method, url, body)
+	var idempotencyKey string
+	if method == http.MethodPost || method == http.MethodDelete {
+		idempotencyKey = generateIdempotencyKey()
+	}
+
+	policy := c.retryPolicy
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		body, retryAfter, err := c.doRestRequestOnce(ctx, method, reqURL, jsonData, idempotencyKey)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || !policy.RetryableStatus[httpErr.StatusCode] || attempt == policy.MaxAttempts {
+			return "", err
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = backoffDelay(attempt, policy.BaseDelay, policy.MaxDelay)
+		}
+		if !sleepCtx(ctx, delay) {
+			return "", ctx.Err()
+		}
+	}
+
+	return "", lastErr
+}
+
+// doRestRequestOnce performs a single HTTP attempt and returns the parsed
+// Retry-After delay (zero if absent) alongside any error.
+func (c *SyrosClient) doRestRequestOnce(ctx context.Context, method, reqURL string, jsonData []byte, idempotencyKey string) (string, time.Duration, error) {
+	var body io.Reader
+	if jsonData != nil {
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 
-	if data != nil {
+	if jsonData != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	if c.interceptor != nil {
+		if err := c.interceptor(req); err != nil {
+			return "", 0, err
+		}
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", 0, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		return "", retryAfter, &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
-	return string(responseBody), nil
+	return string(responseBody), 0, nil
 }
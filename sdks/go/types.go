@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// LockRequest is the typed payload for AcquireLockTyped.
+type LockRequest struct {
+	Key        string  `json:"key"`
+	Owner      string  `json:"owner"`
+	TTLSeconds *int64  `json:"ttl_seconds,omitempty"`
+	Metadata   *string `json:"metadata,omitempty"`
+}
+
+// Lock is the typed result of acquiring a distributed lock. FencingToken is
+// a monotonically increasing number the server bumps on every successful
+// acquire/renew; pass it to downstream stores so they can reject writes
+// from a session that has since lost (and someone else re-acquired) the
+// lock.
+type Lock struct {
+	LockID       string    `json:"lock_id"`
+	Key          string    `json:"key"`
+	Owner        string    `json:"owner"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	FencingToken int64     `json:"fencing_token"`
+}
+
+// LockReleaseResult is the typed result of releasing a distributed lock. A
+// released lock has no live expiry or fencing token, so this intentionally
+// doesn't reuse Lock.
+type LockReleaseResult struct {
+	Key     string `json:"key"`
+	LockID  string `json:"lock_id"`
+	Message string `json:"message,omitempty"`
+}
+
+// LockStatus is the typed result of GetLockStatusTyped.
+type LockStatus struct {
+	Key      string `json:"key"`
+	IsLocked bool   `json:"is_locked"`
+	Owner    string `json:"owner,omitempty"`
+	LockID   string `json:"lock_id,omitempty"`
+}
+
+// Saga is the typed result of starting a saga.
+type Saga struct {
+	SagaID string `json:"saga_id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// SagaStepStatus is the status of a single saga step.
+type SagaStepStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SagaStatus is the typed result of GetSagaStatusTyped.
+type SagaStatus struct {
+	SagaID string           `json:"saga_id"`
+	Status string           `json:"status"`
+	Steps  []SagaStepStatus `json:"steps"`
+}
+
+// CacheEntry is the typed result of a cache read or write.
+type CacheEntry struct {
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Message string          `json:"message,omitempty"`
+}
+
+// Do sends a REST request and decodes the JSON response into a *T. It is the
+// generic building block behind the Typed client methods, and is exported so
+// callers can reach endpoints this SDK doesn't wrap yet without falling back
+// to untyped map[string]interface{} handling.
+func Do[T any](ctx context.Context, c *SyrosClient, method, path string, body interface{}) (*T, error) {
+	resp, err := c.sendRestRequestRawCtx(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
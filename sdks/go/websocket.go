@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketOptions configures the resilient WebSocket connection started by
+// StartWebSocket: heartbeat timing and reconnect backoff.
+type WebSocketOptions struct {
+	// PingInterval is how often the client sends a ping frame to the server.
+	PingInterval time.Duration
+	// PongWait is how long the client waits for a pong before considering
+	// the connection dead.
+	PongWait time.Duration
+	// WriteWait is the deadline for a single write (ping or message).
+	WriteWait time.Duration
+	// MinReconnectDelay and MaxReconnectDelay bound the exponential backoff
+	// (with jitter) used between reconnect attempts.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+}
+
+// DefaultWebSocketOptions returns the options used by StartWebSocket when
+// none are supplied.
+func DefaultWebSocketOptions() WebSocketOptions {
+	return WebSocketOptions{
+		PingInterval:      30 * time.Second,
+		PongWait:          60 * time.Second,
+		WriteWait:         10 * time.Second,
+		MinReconnectDelay: 500 * time.Millisecond,
+		MaxReconnectDelay: 30 * time.Second,
+	}
+}
+
+type wsOutbound struct {
+	messageType int
+	data        []byte
+}
+
+// StartWebSocket opens a resilient WebSocket connection that automatically
+// reconnects with exponential backoff and jitter, sends periodic pings, and
+// replays any topics registered via SubscribeTopic after each reconnect.
+// It is safe to call SubscribeTopic/Unsubscribe/SendWebSocketMessage before
+// or after the initial connection succeeds.
+func (c *SyrosClient) StartWebSocket(opts ...WebSocketOptions) error {
+	c.wsMu.Lock()
+	if c.wsStopCh != nil {
+		c.wsMu.Unlock()
+		return fmt.Errorf("WebSocket already started")
+	}
+
+	o := DefaultWebSocketOptions()
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	c.wsOpts = o
+	c.wsStopCh = make(chan struct{})
+	c.wsSendCh = make(chan wsOutbound, 32)
+	c.wsLoopDone = make(chan struct{})
+	stopCh := c.wsStopCh
+	loopDone := c.wsLoopDone
+	c.wsMu.Unlock()
+
+	go func() {
+		defer close(loopDone)
+		c.wsReconnectLoop(stopCh)
+	}()
+	return nil
+}
+
+// StopWebSocket stops the reconnect loop and waits for the active
+// connection, if any, to be closed. It is idempotent and safe to call
+// multiple times. The live *websocket.Conn is only ever written to by the
+// session goroutine in runWebSocketSession, never by StopWebSocket itself,
+// so a concurrent reconnect can't race a Stop-triggered close on the same
+// connection.
+func (c *SyrosClient) StopWebSocket() {
+	c.wsMu.Lock()
+	if c.wsStopCh == nil {
+		c.wsMu.Unlock()
+		return
+	}
+	close(c.wsStopCh)
+	c.wsStopCh = nil
+	loopDone := c.wsLoopDone
+	c.wsLoopDone = nil
+	c.wsMu.Unlock()
+
+	if loopDone != nil {
+		<-loopDone
+	}
+}
+
+// wsSubscription is a registered topic handler plus a function that builds
+// the "subscribe" payload to (re)send. The payload is rebuilt on every
+// reconnect so it can carry up-to-date resume state (e.g. an "after" event
+// cursor) rather than just the bare topic name.
+type wsSubscription struct {
+	handler func([]byte)
+	payload func() map[string]interface{}
+}
+
+// SubscribeTopic registers a handler for messages published on topic and, if
+// connected, sends a "subscribe" message to the server. The subscription is
+// remembered so it is automatically replayed after a reconnect.
+func (c *SyrosClient) SubscribeTopic(topic string, handler func(msg []byte)) error {
+	return c.subscribeWS(topic, handler, func() map[string]interface{} {
+		return map[string]interface{}{"topic": topic}
+	})
+}
+
+// Unsubscribe removes a topic handler and, if connected, tells the server to
+// stop delivering messages for that topic.
+func (c *SyrosClient) Unsubscribe(topic string) error {
+	c.subsMu.Lock()
+	delete(c.subscriptions, topic)
+	c.subsMu.Unlock()
+
+	return c.sendWSMessage("unsubscribe", map[string]interface{}{"topic": topic})
+}
+
+func (c *SyrosClient) subscribeWS(topic string, handler func([]byte), payload func() map[string]interface{}) error {
+	c.subsMu.Lock()
+	if c.subscriptions == nil {
+		c.subscriptions = make(map[string]*wsSubscription)
+	}
+	c.subscriptions[topic] = &wsSubscription{handler: handler, payload: payload}
+	c.subsMu.Unlock()
+
+	return c.sendWSMessage("subscribe", payload())
+}
+
+func (c *SyrosClient) sendWSMessage(messageType string, data interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"type": messageType,
+		"data": data,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.wsMu.Lock()
+	sendCh := c.wsSendCh
+	c.wsMu.Unlock()
+	if sendCh == nil {
+		// StartWebSocket has not been called. Subscriptions are replayed
+		// via resubscribeAll once it connects; other messages are dropped.
+		return nil
+	}
+
+	select {
+	case sendCh <- wsOutbound{messageType: websocket.TextMessage, data: payload}:
+		return nil
+	default:
+		return fmt.Errorf("WebSocket send buffer full")
+	}
+}
+
+func (c *SyrosClient) wsReconnectLoop(stopCh chan struct{}) {
+	delay := c.wsOpts.MinReconnectDelay
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial(c.wsURL, nil)
+		if err != nil {
+			if !sleepWithJitter(stopCh, delay) {
+				return
+			}
+			delay = nextBackoff(delay, c.wsOpts.MaxReconnectDelay)
+			continue
+		}
+
+		delay = c.wsOpts.MinReconnectDelay
+		c.wsMu.Lock()
+		c.wsConn = conn
+		c.wsMu.Unlock()
+
+		c.resubscribeAll()
+
+		clean := c.runWebSocketSession(conn, stopCh)
+
+		c.wsMu.Lock()
+		if c.wsConn == conn {
+			c.wsConn = nil
+		}
+		c.wsMu.Unlock()
+
+		if clean {
+			return
+		}
+
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+	}
+}
+
+// runWebSocketSession runs the read and ping pumps for a single connection
+// until it fails or the client is stopped. It returns true if the session
+// ended because of a clean server-initiated close or an explicit Stop.
+func (c *SyrosClient) runWebSocketSession(conn *websocket.Conn, stopCh chan struct{}) bool {
+	sessionDone := make(chan struct{})
+	readErr := make(chan error, 1)
+
+	conn.SetReadDeadline(time.Now().Add(c.wsOpts.PongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(c.wsOpts.PongWait))
+		return nil
+	})
+
+	go func() {
+		defer close(sessionDone)
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			c.dispatchMessage(message)
+		}
+	}()
+
+	ticker := time.NewTicker(c.wsOpts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			writeCloseMessage(conn)
+			conn.Close()
+			<-sessionDone
+			return true
+
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(c.wsOpts.WriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				conn.Close()
+				<-sessionDone
+				return false
+			}
+
+		case out := <-c.wsSendCh:
+			conn.SetWriteDeadline(time.Now().Add(c.wsOpts.WriteWait))
+			if err := conn.WriteMessage(out.messageType, out.data); err != nil {
+				conn.Close()
+				<-sessionDone
+				return false
+			}
+
+		case err := <-readErr:
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) && closeErr.Code == websocket.CloseNormalClosure {
+				return true
+			}
+			return false
+		}
+	}
+}
+
+func (c *SyrosClient) dispatchMessage(message []byte) {
+	var envelope struct {
+		Type  string          `json:"type"`
+		Topic string          `json:"topic"`
+		Data  json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return
+	}
+
+	c.subsMu.RLock()
+	sub, ok := c.subscriptions[envelope.Topic]
+	c.subsMu.RUnlock()
+	if ok {
+		sub.handler(message)
+	}
+}
+
+// hasWebSocket reports whether a resilient WebSocket connection is currently
+// established.
+func (c *SyrosClient) hasWebSocket() bool {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	return c.wsStopCh != nil && c.wsConn != nil
+}
+
+func (c *SyrosClient) resubscribeAll() {
+	c.subsMu.RLock()
+	payloads := make([]map[string]interface{}, 0, len(c.subscriptions))
+	for _, sub := range c.subscriptions {
+		payloads = append(payloads, sub.payload())
+	}
+	c.subsMu.RUnlock()
+
+	for _, payload := range payloads {
+		c.sendWSMessage("subscribe", payload)
+	}
+}
+
+func writeCloseMessage(conn *websocket.Conn) {
+	conn.SetWriteDeadline(time.Now().Add(time.Second))
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+func nextBackoff(current, max time.Duration) time.Duration {
+	next := current * 2
+	if next > max {
+		next = max
+	}
+	return next
+}
+
+// sleepWithJitter waits for roughly delay (±25%) or returns false early if
+// stopCh is closed.
+func sleepWithJitter(stopCh chan struct{}, delay time.Duration) bool {
+	jitter := time.Duration(rand.Int63n(int64(delay)/2+1)) - delay/4
+	timer := time.NewTimer(delay + jitter)
+	defer timer.Stop()
+
+	select {
+	case <-stopCh:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestWSServer starts an httptest server that upgrades every request to
+// a WebSocket and echoes nothing back, just enough for the reconnect/stop
+// lifecycle to exercise a real connection.
+func newTestWSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestStartStopWebSocketNoConcurrentConnClose exercises StartWebSocket
+// followed by StopWebSocket while a session is live; run with -race, it
+// catches a session goroutine and StopWebSocket both writing to the same
+// *websocket.Conn.
+func TestStartStopWebSocketNoConcurrentConnClose(t *testing.T) {
+	server := newTestWSServer(t)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	c := NewSyrosClientWithURLs(server.URL, wsURL)
+	if err := c.StartWebSocket(WebSocketOptions{
+		PingInterval:      20 * time.Millisecond,
+		PongWait:          time.Second,
+		WriteWait:         time.Second,
+		MinReconnectDelay: 10 * time.Millisecond,
+		MaxReconnectDelay: 50 * time.Millisecond,
+	}); err != nil {
+		t.Fatalf("StartWebSocket() error = %v", err)
+	}
+
+	// Give the reconnect loop time to dial and establish a live session
+	// before we race it with Stop.
+	deadline := time.Now().Add(time.Second)
+	for !c.hasWebSocket() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !c.hasWebSocket() {
+		t.Fatal("WebSocket never connected")
+	}
+
+	c.StopWebSocket()
+
+	if c.hasWebSocket() {
+		t.Error("hasWebSocket() = true after StopWebSocket, want false")
+	}
+
+	// Idempotent: must not block or panic on a second call.
+	c.StopWebSocket()
+}